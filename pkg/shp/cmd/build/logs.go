@@ -0,0 +1,256 @@
+package build
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	buildclientset "github.com/shipwright-io/build/pkg/client/clientset/versioned"
+
+	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+	"github.com/shipwright-io/cli/pkg/shp/reactor"
+	"github.com/shipwright-io/cli/pkg/shp/tail"
+
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
+)
+
+// LogsCommand represents the `build logs` sub-command, which streams the logs of every step
+// container of a BuildRun, similar to `kubectl logs` but aware of Shipwright's init+step container
+// layout.
+type LogsCommand struct {
+	cmd *cobra.Command
+
+	ioStreams *genericclioptions.IOStreams
+	clientset kubernetes.Interface
+	shpClient buildclientset.Interface
+	pw        *reactor.PodWatcher
+	logTail   *tail.Tail
+
+	buildName    string
+	buildRunName string // explicit BuildRun name, set via resource-type argument or --buildrun
+	container    string // --container, restricts output to a single step
+
+	follow     bool
+	previous   bool
+	timestamps bool
+	since      time.Duration
+}
+
+const buildLogsLongDesc = `
+Prints the logs of every init and step container of the latest BuildRun for
+the given Build, in order, similar to 'kubectl logs'. For example:
+
+	$ shp build logs my-app
+
+The argument also accepts "build/<name>", same as a bare name, or
+"buildrun/<name>" or "br/<name>" to target a BuildRun directly instead of
+resolving the latest one for a Build.
+`
+
+// Cmd returns cobra.Command object of the logs sub-command.
+func (l *LogsCommand) Cmd() *cobra.Command {
+	return l.cmd
+}
+
+// parseResourceArg splits a "<type>/<name>" argument into its type and name, defaulting to the
+// "build" type when no prefix is given, inspired by kubectl's resource-type parsing. It errors out
+// on an unrecognized type prefix rather than silently folding it into the name.
+func parseResourceArg(arg string) (kind, name string, err error) {
+	parts := strings.SplitN(arg, "/", 2)
+	if len(parts) == 1 {
+		return "build", parts[0], nil
+	}
+	switch parts[0] {
+	case "br", "buildrun":
+		return "buildrun", parts[1], nil
+	case "build":
+		return "build", parts[1], nil
+	default:
+		return "", "", fmt.Errorf("unsupported resource type %q, must be one of: build, buildrun, br", parts[0])
+	}
+}
+
+// Complete parses the resource argument and instantiates the kubernetes and Shipwright clients.
+func (l *LogsCommand) Complete(params params.Params, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("build or buildrun name is not informed")
+	}
+
+	kind, name, err := parseResourceArg(args[0])
+	if err != nil {
+		return err
+	}
+	switch kind {
+	case "buildrun":
+		l.buildRunName = name
+	default:
+		l.buildName = name
+	}
+
+	if l.clientset, err = params.ClientSet(); err != nil {
+		return err
+	}
+	if l.shpClient, err = params.ShipwrightClientSet(); err != nil {
+		return err
+	}
+	l.logTail = tail.NewTail(l.Cmd().Context(), l.clientset)
+	return nil
+}
+
+// Validate checks that either a Build or a BuildRun name was informed.
+func (l *LogsCommand) Validate() error {
+	if l.buildName == "" && l.buildRunName == "" {
+		return fmt.Errorf("build or buildrun name is not informed")
+	}
+	return nil
+}
+
+// latestBuildRun returns the most recently created BuildRun owned by the given Build.
+func (l *LogsCommand) latestBuildRun(namespace string) (*buildv1alpha1.BuildRun, error) {
+	list, err := l.shpClient.ShipwrightV1alpha1().BuildRuns(namespace).List(l.cmd.Context(), metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("build.shipwright.io/name=%s", l.buildName),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(list.Items) == 0 {
+		return nil, fmt.Errorf("no BuildRun found for build %q", l.buildName)
+	}
+	sort.Slice(list.Items, func(i, j int) bool {
+		return list.Items[i].CreationTimestamp.After(list.Items[j].CreationTimestamp.Time)
+	})
+	return &list.Items[0], nil
+}
+
+// resolveBuildRun returns the BuildRun to stream logs for, either the explicit one informed on the
+// command-line or the latest one for the Build.
+func (l *LogsCommand) resolveBuildRun(namespace string) (*buildv1alpha1.BuildRun, error) {
+	if l.buildRunName != "" {
+		return l.shpClient.ShipwrightV1alpha1().BuildRuns(namespace).Get(l.cmd.Context(), l.buildRunName, metav1.GetOptions{})
+	}
+	return l.latestBuildRun(namespace)
+}
+
+// podForBuildRun returns the build pod created for the given BuildRun.
+func (l *LogsCommand) podForBuildRun(namespace, buildRunName string) (*corev1.Pod, error) {
+	list, err := l.clientset.CoreV1().Pods(namespace).List(l.cmd.Context(), metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("buildrun.shipwright.io/name=%s", buildRunName),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(list.Items) == 0 {
+		return nil, fmt.Errorf("no pod found for buildrun %q", buildRunName)
+	}
+	return &list.Items[0], nil
+}
+
+// printLogs streams a single container's logs to ioStreams.Out, honoring --previous, --timestamps
+// and --since.
+func (l *LogsCommand) printLogs(namespace, pod, container string) error {
+	opts := &corev1.PodLogOptions{
+		Container:  container,
+		Follow:     false,
+		Previous:   l.previous,
+		Timestamps: l.timestamps,
+	}
+	if l.since > 0 {
+		since := int64(l.since.Seconds())
+		opts.SinceSeconds = &since
+	}
+
+	fmt.Fprintf(l.ioStreams.Out, "==> %s <==\n", container)
+	stream, err := l.clientset.CoreV1().Pods(namespace).GetLogs(pod, opts).Stream(l.cmd.Context())
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+	_, err = io.Copy(l.ioStreams.Out, stream)
+	return err
+}
+
+// Run locates the BuildRun's pod and either prints its current logs in order, or follows them
+// live until the build completes.
+func (l *LogsCommand) Run(params params.Params, ioStreams *genericclioptions.IOStreams) error {
+	l.ioStreams = ioStreams
+	l.logTail.WithIOStreams(ioStreams)
+	l.logTail.WithTimestamps(l.timestamps)
+	if l.since > 0 {
+		l.logTail.WithSince(l.since)
+	}
+	namespace := params.Namespace()
+
+	br, err := l.resolveBuildRun(namespace)
+	if err != nil {
+		return err
+	}
+
+	pod, err := l.podForBuildRun(namespace, br.Name)
+	if err != nil {
+		return err
+	}
+
+	containers := append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+	if !l.follow {
+		for _, c := range containers {
+			if l.container != "" && l.container != c.Name {
+				continue
+			}
+			if err := l.printLogs(namespace, pod.GetName(), c.Name); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	l.pw, err = reactor.NewPodWatcher(l.cmd.Context(), l.clientset, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("buildrun.shipwright.io/name=%s", br.Name),
+	}, namespace)
+	if err != nil {
+		return err
+	}
+	l.pw.WithOnPodModifiedFn(func(pod *corev1.Pod) error {
+		for _, c := range append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...) {
+			if l.container != "" && l.container != c.Name {
+				continue
+			}
+			l.logTail.Start(pod.GetNamespace(), pod.GetName(), c.Name)
+		}
+		switch pod.Status.Phase {
+		case corev1.PodSucceeded, corev1.PodFailed:
+			l.logTail.Stop()
+			l.pw.Stop()
+		}
+		return nil
+	})
+	_, err = l.pw.Start()
+	return err
+}
+
+// logsCmd instantiates the "build logs" sub-command.
+func logsCmd() runner.SubCommand {
+	cmd := &cobra.Command{
+		Use:   "logs <build>",
+		Short: "See BuildRun log streams",
+		Long:  buildLogsLongDesc,
+	}
+	logsCommand := &LogsCommand{
+		cmd: cmd,
+	}
+	cmd.Flags().StringVar(&logsCommand.buildRunName, "buildrun", "", "Name of the BuildRun to fetch logs for, instead of the latest for the Build")
+	cmd.Flags().BoolVarP(&logsCommand.follow, "follow", "f", false, "Stream the logs until the build completes")
+	cmd.Flags().BoolVar(&logsCommand.previous, "previous", false, "Print the logs from a previous, failed instance of the container")
+	cmd.Flags().BoolVar(&logsCommand.timestamps, "timestamps", false, "Include timestamps on each line of log output")
+	cmd.Flags().DurationVar(&logsCommand.since, "since", 0, "Only return logs newer than a relative duration")
+	cmd.Flags().StringVar(&logsCommand.container, "container", "", "Only print logs from this step container")
+	return logsCommand
+}
@@ -0,0 +1,36 @@
+package build
+
+import "testing"
+
+func TestParseResourceArg(t *testing.T) {
+	tests := []struct {
+		name     string
+		arg      string
+		wantKind string
+		wantName string
+		wantErr  bool
+	}{
+		{name: "bare name defaults to build", arg: "my-build", wantKind: "build", wantName: "my-build"},
+		{name: "explicit build prefix", arg: "build/my-build", wantKind: "build", wantName: "my-build"},
+		{name: "buildrun prefix", arg: "buildrun/my-buildrun", wantKind: "buildrun", wantName: "my-buildrun"},
+		{name: "br shorthand prefix", arg: "br/my-buildrun", wantKind: "buildrun", wantName: "my-buildrun"},
+		{name: "unrecognized prefix errors out", arg: "pod/foo", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind, name, err := parseResourceArg(tt.arg)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseResourceArg(%q) returned no error, want one", tt.arg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseResourceArg(%q) returned unexpected error: %s", tt.arg, err)
+			}
+			if kind != tt.wantKind || name != tt.wantName {
+				t.Errorf("parseResourceArg(%q) = (%q, %q), want (%q, %q)", tt.arg, kind, name, tt.wantKind, tt.wantName)
+			}
+		})
+	}
+}
@@ -1,9 +1,13 @@
 package build
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"os"
+	"os/signal"
 	"sync"
+	"syscall"
 	"time"
 
 	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
@@ -12,13 +16,16 @@ import (
 	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
 	"github.com/shipwright-io/cli/pkg/shp/flags"
 	"github.com/shipwright-io/cli/pkg/shp/params"
+	"github.com/shipwright-io/cli/pkg/shp/printer"
 	"github.com/shipwright-io/cli/pkg/shp/reactor"
 	"github.com/shipwright-io/cli/pkg/shp/tail"
+	"github.com/shipwright-io/cli/pkg/shp/wait"
 
 	"github.com/spf13/cobra"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
 
@@ -36,7 +43,13 @@ type RunCommand struct {
 	buildRunName string
 	buildRunSpec *buildv1alpha1.BuildRunSpec // stores command-line flags
 	shpClientset buildclientset.Interface
-	follow       bool // flag to tail pod logs
+	follow       bool          // flag to tail pod logs
+	wait         bool          // flag to block on the BuildRun completing, when not following
+	waitTimeout  time.Duration // how long to block for with --wait
+	output       string        // -o/--output format
+	timeout      time.Duration // --timeout, aborts a followed build after this long
+	cancelReason string        // set once the build is canceled locally, either by signal or timeout
+	sigCh        chan os.Signal
 	watchLock    sync.Mutex
 }
 
@@ -53,7 +66,7 @@ func (r *RunCommand) Cmd() *cobra.Command {
 }
 
 // Complete picks the build resource name from arguments, and instantiate additional components.
-func (r *RunCommand) Complete(params *params.Params, args []string) error {
+func (r *RunCommand) Complete(params params.Params, args []string) error {
 	switch len(args) {
 	case 1:
 		r.buildName = args[0]
@@ -67,6 +80,16 @@ func (r *RunCommand) Complete(params *params.Params, args []string) error {
 	}
 	r.logTail = tail.NewTail(r.Cmd().Context(), clientset)
 
+	// only the --follow path ever drains this channel (in watchForCancellation); registering it
+	// unconditionally would disable Go's default terminate-on-signal behavior for plain `run` and
+	// `run --wait` invocations, leaving them stuck until --wait-timeout elapses
+	if r.follow {
+		// registered here, ahead of Run(), so an early Ctrl-C is not missed while the BuildRun is
+		// still being created
+		r.sigCh = make(chan os.Signal, 2)
+		signal.Notify(r.sigCh, os.Interrupt, syscall.SIGTERM)
+	}
+
 	// overwriting build-ref name to use what's on arguments
 	return r.Cmd().Flags().Set(flags.BuildrefNameFlag, r.buildName)
 }
@@ -92,6 +115,26 @@ func (r *RunCommand) tailLogs(pod *corev1.Pod) {
 	}
 }
 
+// emitPhase reports a pod phase transition, either as a human-readable line or, under -o json, as
+// a structured "PodPhase" event.
+func (r *RunCommand) emitPhase(pod *corev1.Pod, msg string) {
+	if printer.IsJSONStream(r.output) {
+		_ = printer.WriteEvent(r.ioStreams.Out, printer.Event{Type: "PodPhase", Phase: string(pod.Status.Phase), Pod: pod.GetName()})
+		return
+	}
+	fmt.Fprintf(r.ioStreams.Out, msg)
+}
+
+// emitResult reports the final build outcome, either as a human-readable line or, under -o json,
+// as a structured "Result" event.
+func (r *RunCommand) emitResult(status, msg string) {
+	if printer.IsJSONStream(r.output) {
+		_ = printer.WriteEvent(r.ioStreams.Out, printer.Event{Type: "Result", Status: status})
+		return
+	}
+	fmt.Fprintf(r.ioStreams.Out, msg)
+}
+
 // onEvent reacts on pod state changes, to start and stop tailing container logs.
 func (r *RunCommand) onEvent(pod *corev1.Pod) error {
 	// found more data races during unit testing with concurrent events coming in
@@ -105,27 +148,31 @@ func (r *RunCommand) onEvent(pod *corev1.Pod) error {
 		r.tailLogs(pod)
 	case corev1.PodFailed:
 		msg := ""
+		status := "Failed"
 		br, err := r.shpClientset.ShipwrightV1alpha1().BuildRuns(pod.Namespace).Get(r.cmd.Context(), r.buildRunName, metav1.GetOptions{})
 		switch {
 		case err == nil && br.IsCanceled():
 			msg = fmt.Sprintf("BuildRun '%s' has been canceled.\n", br.Name)
+			status = "Canceled"
 		case err == nil && br.DeletionTimestamp != nil:
 			msg = fmt.Sprintf("BuildRun '%s' has been deleted.\n", br.Name)
+			status = "Deleted"
 		case pod.DeletionTimestamp != nil:
 			msg = fmt.Sprintf("Pod '%s' has been deleted.\n", pod.GetName())
+			status = "Deleted"
 		default:
 			msg = fmt.Sprintf("Pod '%s' has failed!\n", pod.GetName())
 			err = fmt.Errorf("build pod '%s' has failed", pod.GetName())
 		}
 		// see if because of deletion or cancelation
-		fmt.Fprintf(r.ioStreams.Out, msg)
+		r.emitResult(status, msg)
 		r.stop()
 		return err
 	case corev1.PodSucceeded:
-		fmt.Fprintf(r.ioStreams.Out, "Pod '%s' has succeeded!\n", pod.GetName())
+		r.emitResult("Succeeded", fmt.Sprintf("Pod '%s' has succeeded!\n", pod.GetName()))
 		r.stop()
 	default:
-		fmt.Fprintf(r.ioStreams.Out, "Pod '%s' is in state %q...\n", pod.GetName(), string(pod.Status.Phase))
+		r.emitPhase(pod, fmt.Sprintf("Pod '%s' is in state %q...\n", pod.GetName(), string(pod.Status.Phase)))
 		// handle any issues with pulling images that may fail
 		for _, c := range pod.Status.Conditions {
 			if c.Type == corev1.PodInitialized || c.Type == corev1.ContainersReady {
@@ -138,14 +185,65 @@ func (r *RunCommand) onEvent(pod *corev1.Pod) error {
 	return nil
 }
 
+// printBuildRun renders br using the -o/--output format informed on the command-line. client-go
+// clears TypeMeta on objects returned from Create/Get, so it's backfilled here first -- otherwise
+// NamePrinter has no Kind to print and JSONPrinter/YAMLPrinter omit kind/apiVersion entirely.
+func (r *RunCommand) printBuildRun(ioStreams *genericclioptions.IOStreams, br *buildv1alpha1.BuildRun) error {
+	p, err := printer.NewPrinter(r.output)
+	if err != nil {
+		return err
+	}
+	br.TypeMeta = metav1.TypeMeta{
+		Kind:       "BuildRun",
+		APIVersion: buildv1alpha1.SchemeGroupVersion.String(),
+	}
+	return p.PrintObj(br, ioStreams.Out)
+}
+
 // stop invoke stop on streaming components.
 func (r *RunCommand) stop() {
 	r.logTail.Stop()
 	r.pw.Stop()
 }
 
+// cancelBuildRun patches the BuildRun to BuildRunStateCancel, asking the Shipwright controller to
+// stop it. Uses its own context so an expired --timeout doesn't also abort the PATCH itself.
+func (r *RunCommand) cancelBuildRun(namespace, name string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	patch := []byte(fmt.Sprintf(`{"spec":{"state":%q}}`, buildv1alpha1.BuildRunStateCancel))
+	if _, err := r.shpClientset.ShipwrightV1alpha1().BuildRuns(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		fmt.Fprintf(r.ioStreams.Out, "Failed to cancel BuildRun '%s': %s\n", name, err)
+	}
+}
+
+// watchForCancellation blocks until ctx expires (--timeout), a SIGINT/SIGTERM is received, or ctx
+// is otherwise done because the build already finished on its own. On timeout or signal it PATCHes
+// the BuildRun to BuildRunStateCancel and stops the local streamers; a second SIGINT/SIGTERM while
+// waiting on the controller escalates to an immediate local exit.
+func (r *RunCommand) watchForCancellation(ctx context.Context, namespace, buildRunName string) {
+	select {
+	case <-ctx.Done():
+		if ctx.Err() != context.DeadlineExceeded {
+			return
+		}
+		r.cancelReason = "timed out"
+	case <-r.sigCh:
+		r.cancelReason = "canceled by user"
+	}
+
+	fmt.Fprintf(r.ioStreams.Out, "Canceling BuildRun '%s', reason: %s...\n", buildRunName, r.cancelReason)
+	r.cancelBuildRun(namespace, buildRunName)
+	r.stop()
+
+	<-r.sigCh
+	fmt.Fprintln(r.ioStreams.Out, "Received second interrupt, exiting immediately without waiting for the controller.")
+	os.Exit(130)
+}
+
 // Run creates a BuildRun resource based on Build's name informed on arguments.
-func (r *RunCommand) Run(params *params.Params, ioStreams *genericclioptions.IOStreams) error {
+func (r *RunCommand) Run(params params.Params, ioStreams *genericclioptions.IOStreams) error {
 	// ran into some data race conditions during unit test with this starting up, but pod events
 	// coming in before we completed initialization below
 	r.watchLock.Lock()
@@ -168,11 +266,48 @@ func (r *RunCommand) Run(params *params.Params, ioStreams *genericclioptions.IOS
 	}
 
 	if !r.follow {
-		fmt.Fprintf(ioStreams.Out, "BuildRun created %q for build %q\n", br.GetName(), r.buildName)
+		if r.wait {
+			shpClientset, err := params.ShipwrightClientSet()
+			if err != nil {
+				return err
+			}
+			waited, err := wait.WaitForBuildRunCondition(r.cmd.Context(), shpClientset, params.Namespace(), br.GetName(), wait.Completed, r.waitTimeout)
+			if waited != nil {
+				br = waited
+			}
+			if err != nil {
+				if r.output != "" {
+					if printErr := r.printBuildRun(ioStreams, br); printErr != nil {
+						return printErr
+					}
+					return err
+				}
+				fmt.Fprintf(ioStreams.Out, "BuildRun '%s' has failed: %s\n", br.GetName(), err)
+				return err
+			}
+		}
+		if r.output != "" {
+			return r.printBuildRun(ioStreams, br)
+		}
+		if r.wait {
+			fmt.Fprintf(ioStreams.Out, "BuildRun '%s' has succeeded.\n", br.GetName())
+		} else {
+			fmt.Fprintf(ioStreams.Out, "BuildRun created %q for build %q\n", br.GetName(), r.buildName)
+		}
 		return nil
 	}
 
+	if r.output != "" && !printer.IsJSONStream(r.output) {
+		if err := r.printBuildRun(ioStreams, br); err != nil {
+			return err
+		}
+	}
+
 	r.ioStreams = ioStreams
+	r.logTail.WithIOStreams(ioStreams)
+	if printer.IsJSONStream(r.output) {
+		r.logTail.WithJSONOutput(ioStreams.Out)
+	}
 	kclientset, err := params.ClientSet()
 	if err != nil {
 		return err
@@ -190,7 +325,14 @@ func (r *RunCommand) Run(params *params.Params, ioStreams *genericclioptions.IOS
 		r.buildName,
 		br.GetName(),
 	)}
-	r.pw, err = reactor.NewPodWatcher(r.Cmd().Context(), kclientset, listOpts, params.Namespace())
+	followCtx := r.Cmd().Context()
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		followCtx, cancel = context.WithTimeout(followCtx, r.timeout)
+		defer cancel()
+	}
+
+	r.pw, err = reactor.NewPodWatcher(followCtx, kclientset, listOpts, params.Namespace())
 	if err != nil {
 		return err
 	}
@@ -199,7 +341,13 @@ func (r *RunCommand) Run(params *params.Params, ioStreams *genericclioptions.IOS
 	// cannot defer with unlock up top because r.pw.Start() blocks;  but the erroring out above kills the
 	// cli invocation, so it does not matter
 	r.watchLock.Unlock()
+
+	go r.watchForCancellation(followCtx, params.Namespace(), br.GetName())
+
 	_, err = r.pw.Start()
+	if r.cancelReason != "" {
+		fmt.Fprintf(ioStreams.Out, "BuildRun '%s' was %s.\n", br.GetName(), r.cancelReason)
+	}
 	return err
 }
 
@@ -217,5 +365,9 @@ func runCmd() runner.SubCommand {
 		watchLock:       sync.Mutex{},
 	}
 	cmd.Flags().BoolVarP(&runCommand.follow, "follow", "F", runCommand.follow, "Start a build and watch its log until it completes or fails.")
+	cmd.Flags().BoolVar(&runCommand.wait, "wait", runCommand.wait, "Wait for the BuildRun to complete before returning, without streaming its log; exits non-zero on failure. Ignored when --follow is used.")
+	cmd.Flags().DurationVar(&runCommand.waitTimeout, "wait-timeout", 10*time.Minute, "Maximum time to wait for the BuildRun to complete, used with --wait.")
+	cmd.Flags().StringVarP(&runCommand.output, "output", "o", "", "Output format for the created BuildRun, one of: "+printer.SupportedOutputs+". With --follow and -o json, streams PodPhase/Log/Result JSON events instead of human-readable text.")
+	cmd.Flags().DurationVar(&runCommand.timeout, "timeout", 0, "Cancel the BuildRun and stop following it after this long. Zero means never. Only applies with --follow.")
 	return runCommand
 }
@@ -0,0 +1,50 @@
+package params
+
+import (
+	buildclientset "github.com/shipwright-io/build/pkg/client/clientset/versioned"
+
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ParamsOption configures a *params during construction via NewParams, letting subcommand tests
+// inject fake clients without touching the filesystem.
+type ParamsOption func(*params)
+
+// WithConfigProvider overrides the ConfigProvider used to resolve the rest.Config and default
+// namespace, e.g. to switch to in-cluster or serialized-kubeconfig resolution.
+func WithConfigProvider(provider ConfigProvider) ParamsOption {
+	return func(p *params) {
+		p.configProvider = provider
+	}
+}
+
+// WithDynamicClient injects a preconfigured dynamic.Interface, bypassing config resolution.
+func WithDynamicClient(client dynamic.Interface) ParamsOption {
+	return func(p *params) {
+		p.client = client
+	}
+}
+
+// WithClientSet injects a preconfigured kubernetes.Interface, bypassing config resolution.
+func WithClientSet(clientset kubernetes.Interface) ParamsOption {
+	return func(p *params) {
+		p.clientset = clientset
+	}
+}
+
+// WithShipwrightClientSet injects a preconfigured buildclientset.Interface, bypassing config
+// resolution.
+func WithShipwrightClientSet(clientset buildclientset.Interface) ParamsOption {
+	return func(p *params) {
+		p.shpClientset = clientset
+	}
+}
+
+// WithNamespace pins the namespace returned by Namespace(), bypassing config resolution.
+func WithNamespace(namespace string) ParamsOption {
+	return func(p *params) {
+		p.namespace = namespace
+		p.namespaceSet = true
+	}
+}
@@ -1,6 +1,8 @@
 package params
 
 import (
+	"os"
+
 	"github.com/pkg/errors"
 	"github.com/spf13/pflag"
 
@@ -11,40 +13,118 @@ import (
 	"k8s.io/client-go/kubernetes"
 )
 
-// Params is a place for Shipwright CLI to store its runtime parameters including configured dynamic
-// client and global flags.
-type Params struct {
+// Params is a place for Shipwright CLI to store its runtime parameters including configured
+// dynamic client and global flags.
+type Params interface {
+	AddFlags(flags *pflag.FlagSet)
+	Client() (dynamic.Interface, error)
+	ClientSet() (kubernetes.Interface, error)
+	ShipwrightClientSet() (buildclientset.Interface, error)
+	Namespace() string
+}
+
+// params is the default Params implementation, resolving its clients from a ConfigProvider that
+// defaults to the on-disk kubeconfig but can be swapped for in-cluster or serialized-kubeconfig
+// resolution, or bypassed altogether with preconfigured fake clients for tests.
+type params struct {
 	client       dynamic.Interface
 	clientset    kubernetes.Interface
 	shpClientset buildclientset.Interface
 
 	configFlags *genericclioptions.ConfigFlags
-	namespace   string
+
+	configProvider ConfigProvider // explicit override, takes precedence over flags/env
+
+	kubeconfigData string // --kubeconfig-data
+	inCluster      bool   // --in-cluster
+
+	namespace    string
+	namespaceSet bool // true when namespace was pinned via WithNamespace
 }
 
-// AddFlags accepts flags and adds program global flags to it
-func (p *Params) AddFlags(flags *pflag.FlagSet) {
+// AddFlags accepts flags and adds program global flags to it.
+func (p *params) AddFlags(flags *pflag.FlagSet) {
 	p.configFlags.AddFlags(flags)
+	flags.StringVar(&p.kubeconfigData, "kubeconfig-data", "", "Serialized kubeconfig content, as an alternative to --kubeconfig or a kubeconfig on disk (env: "+KubeConfigDataEnvVar+")")
+	flags.BoolVar(&p.inCluster, "in-cluster", false, "Use the in-cluster service-account config instead of a kubeconfig, for when shp runs as a step image")
 }
 
-// Client returns preconfigured dynamic client with overrides
-// from global flags and kubernetes configuration set by user
-func (p *Params) Client() (dynamic.Interface, error) {
-	if p.client != nil {
-		return p.client, nil
+// provider resolves which ConfigProvider to use, honoring an explicit override first, then
+// --kubeconfig-data/the environment variable, then --in-cluster, and finally falling back to the
+// on-disk kubeconfig loaded through ConfigFlags. Impersonation flags (--as, --as-group, --as-uid)
+// are parsed by ConfigFlags regardless of provider, and are wired through explicitly for the
+// providers that don't already resolve their config from it.
+func (p *params) provider() ConfigProvider {
+	if p.configProvider != nil {
+		return p.configProvider
 	}
 
-	clientConfig := p.configFlags.ToRawKubeConfigLoader()
+	namespace := ""
+	if p.configFlags.Namespace != nil {
+		namespace = *p.configFlags.Namespace
+	}
+	if data := p.kubeconfigData; data != "" {
+		return p.impersonate(&kubeConfigDataProvider{data: []byte(data), namespace: namespace})
+	}
+	if data := os.Getenv(KubeConfigDataEnvVar); data != "" {
+		return p.impersonate(&kubeConfigDataProvider{data: []byte(data), namespace: namespace})
+	}
+	if p.inCluster {
+		return p.impersonate(&inClusterProvider{namespace: namespace})
+	}
+	return &configFlagsProvider{configFlags: p.configFlags}
+}
+
+// impersonate wraps provider with the impersonation identity parsed by ConfigFlags, if any was
+// informed.
+func (p *params) impersonate(provider ConfigProvider) ConfigProvider {
+	as := ""
+	if p.configFlags.Impersonate != nil {
+		as = *p.configFlags.Impersonate
+	}
+	var asGroup []string
+	if p.configFlags.ImpersonateGroup != nil {
+		asGroup = *p.configFlags.ImpersonateGroup
+	}
+	asUID := ""
+	if p.configFlags.ImpersonateUID != nil {
+		asUID = *p.configFlags.ImpersonateUID
+	}
+	if as == "" && len(asGroup) == 0 && asUID == "" {
+		return provider
+	}
+	return &impersonatingProvider{ConfigProvider: provider, as: as, asGroup: asGroup, asUID: asUID}
+}
 
-	config, err := clientConfig.ClientConfig()
+// resolveNamespace resolves and caches the namespace from the active provider, unless it was
+// pinned via WithNamespace.
+func (p *params) resolveNamespace() error {
+	if p.namespaceSet {
+		return nil
+	}
+	namespace, err := p.provider().Namespace()
 	if err != nil {
-		return nil, err
+		return err
+	}
+	p.namespace = namespace
+	p.namespaceSet = true
+	return nil
+}
+
+// Client returns preconfigured dynamic client with overrides from global flags and kubernetes
+// configuration set by user.
+func (p *params) Client() (dynamic.Interface, error) {
+	if p.client != nil {
+		return p.client, nil
 	}
 
-	p.namespace, _, err = clientConfig.Namespace()
+	config, err := p.provider().ClientConfig()
 	if err != nil {
 		return nil, err
 	}
+	if err := p.resolveNamespace(); err != nil {
+		return nil, err
+	}
 
 	p.client, err = dynamic.NewForConfig(config)
 	if err != nil {
@@ -55,16 +135,18 @@ func (p *Params) Client() (dynamic.Interface, error) {
 }
 
 // ClientSet returns a kubernetes clientset.
-func (p *Params) ClientSet() (kubernetes.Interface, error) {
+func (p *params) ClientSet() (kubernetes.Interface, error) {
 	if p.clientset != nil {
 		return p.clientset, nil
 	}
 
-	clientConfig := p.configFlags.ToRawKubeConfigLoader()
-	config, err := clientConfig.ClientConfig()
+	config, err := p.provider().ClientConfig()
 	if err != nil {
 		return nil, err
 	}
+	if err := p.resolveNamespace(); err != nil {
+		return nil, err
+	}
 
 	p.clientset, err = kubernetes.NewForConfig(config)
 	if err != nil {
@@ -74,16 +156,20 @@ func (p *Params) ClientSet() (kubernetes.Interface, error) {
 	return p.clientset, nil
 }
 
-// ShipwrightClientSet returns a Shipwright Clientset
-func (p *Params) ShipwrightClientSet() (buildclientset.Interface, error) {
+// ShipwrightClientSet returns a Shipwright Clientset.
+func (p *params) ShipwrightClientSet() (buildclientset.Interface, error) {
 	if p.shpClientset != nil {
 		return p.shpClientset, nil
 	}
-	clientConfig := p.configFlags.ToRawKubeConfigLoader()
-	config, err := clientConfig.ClientConfig()
+
+	config, err := p.provider().ClientConfig()
 	if err != nil {
 		return nil, err
 	}
+	if err := p.resolveNamespace(); err != nil {
+		return nil, err
+	}
+
 	p.shpClientset, err = buildclientset.NewForConfig(config)
 	if err != nil {
 		return nil, err
@@ -91,17 +177,26 @@ func (p *Params) ShipwrightClientSet() (buildclientset.Interface, error) {
 	return p.shpClientset, nil
 }
 
-// Namespace returns kubernetes namespace with alle the overrides
-// from command line and kubernetes config
-func (p *Params) Namespace() string {
+// Namespace returns kubernetes namespace with all the overrides from command line and kubernetes
+// config.
+func (p *params) Namespace() string {
+	if !p.namespaceSet {
+		// best-effort: callers are expected to have invoked one of the client constructors first,
+		// but resolve eagerly rather than returning a stale empty string
+		_ = p.resolveNamespace()
+	}
 	return p.namespace
 }
 
-// NewParams creates a new instance of ShipwrightParams and returns it as
-// an interface value
-func NewParams() *Params {
-	p := &Params{}
-	p.configFlags = genericclioptions.NewConfigFlags(true)
-
+// NewParams creates a new Params instance backed by genericclioptions.ConfigFlags, applying any
+// ParamsOption informed. Tests can use WithClientSet, WithShipwrightClientSet, WithDynamicClient
+// and WithNamespace to inject fake clients without touching the filesystem.
+func NewParams(opts ...ParamsOption) Params {
+	p := &params{
+		configFlags: genericclioptions.NewConfigFlags(true),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
 	return p
 }
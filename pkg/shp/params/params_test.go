@@ -0,0 +1,66 @@
+package params_test
+
+import (
+	"testing"
+
+	buildfake "github.com/shipwright-io/build/pkg/client/clientset/versioned/fake"
+
+	"github.com/shipwright-io/cli/pkg/shp/params"
+
+	"k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+// TestNewParamsInjectedClients asserts that the ParamsOption constructors let callers inject fake
+// clients and a fixed namespace without ever touching a kubeconfig on disk.
+func TestNewParamsInjectedClients(t *testing.T) {
+	wantClientset := kubefake.NewSimpleClientset()
+	wantShpClientset := buildfake.NewSimpleClientset()
+	wantDynamic := fake.NewSimpleDynamicClient(nil)
+	wantNamespace := "my-namespace"
+
+	p := params.NewParams(
+		params.WithClientSet(wantClientset),
+		params.WithShipwrightClientSet(wantShpClientset),
+		params.WithDynamicClient(wantDynamic),
+		params.WithNamespace(wantNamespace),
+	)
+
+	gotClientset, err := p.ClientSet()
+	if err != nil {
+		t.Fatalf("ClientSet() returned error: %s", err)
+	}
+	if gotClientset != kubernetes.Interface(wantClientset) {
+		t.Errorf("ClientSet() = %v, want the injected fake", gotClientset)
+	}
+
+	gotShpClientset, err := p.ShipwrightClientSet()
+	if err != nil {
+		t.Fatalf("ShipwrightClientSet() returned error: %s", err)
+	}
+	if gotShpClientset != wantShpClientset {
+		t.Errorf("ShipwrightClientSet() = %v, want the injected fake", gotShpClientset)
+	}
+
+	gotDynamic, err := p.Client()
+	if err != nil {
+		t.Fatalf("Client() returned error: %s", err)
+	}
+	if gotDynamic != wantDynamic {
+		t.Errorf("Client() = %v, want the injected fake", gotDynamic)
+	}
+
+	if got := p.Namespace(); got != wantNamespace {
+		t.Errorf("Namespace() = %q, want %q", got, wantNamespace)
+	}
+}
+
+// TestNewParamsDefaultsToEmptyOptions asserts that NewParams with no options still returns a
+// non-nil Params.
+func TestNewParamsDefaultsToEmptyOptions(t *testing.T) {
+	p := params.NewParams()
+	if p == nil {
+		t.Fatal("NewParams() returned nil")
+	}
+}
@@ -0,0 +1,125 @@
+package params
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// clientConfigFromData parses a serialized kubeconfig blob into a clientcmd.ClientConfig, the same
+// abstraction genericclioptions.ConfigFlags builds from the on-disk file.
+func clientConfigFromData(data []byte) (clientcmd.ClientConfig, error) {
+	rawConfig, err := clientcmd.Load(data)
+	if err != nil {
+		return nil, err
+	}
+	return clientcmd.NewDefaultClientConfig(*rawConfig, &clientcmd.ConfigOverrides{}), nil
+}
+
+// KubeConfigDataEnvVar is the environment variable holding a serialized kubeconfig, used as a
+// fallback for --kubeconfig-data so CI invocations don't need one on disk.
+const KubeConfigDataEnvVar = "SHP_KUBECONFIG_DATA"
+
+// ConfigProvider resolves the rest.Config and default namespace used to build the CLI's clients.
+// Implementations exist for the on-disk kubeconfig (the default), in-cluster config and a
+// serialized kubeconfig blob, so tests and non-interactive invocations don't have to touch the
+// filesystem.
+type ConfigProvider interface {
+	ClientConfig() (*rest.Config, error)
+	Namespace() (string, error)
+}
+
+// configFlagsProvider resolves the config from genericclioptions.ConfigFlags, i.e. the on-disk
+// kubeconfig plus whatever global flags the user informed. This is the default provider.
+type configFlagsProvider struct {
+	configFlags *genericclioptions.ConfigFlags
+}
+
+func (p *configFlagsProvider) ClientConfig() (*rest.Config, error) {
+	return p.configFlags.ToRawKubeConfigLoader().ClientConfig()
+}
+
+func (p *configFlagsProvider) Namespace() (string, error) {
+	namespace, _, err := p.configFlags.ToRawKubeConfigLoader().Namespace()
+	return namespace, err
+}
+
+// inClusterProvider resolves the config from the service-account mounted into the running pod,
+// for when shp itself runs as a step image inside the cluster.
+type inClusterProvider struct {
+	namespace string
+}
+
+func (p *inClusterProvider) ClientConfig() (*rest.Config, error) {
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load in-cluster config")
+	}
+	return config, nil
+}
+
+func (p *inClusterProvider) Namespace() (string, error) {
+	if p.namespace != "" {
+		return p.namespace, nil
+	}
+	data, err := os.ReadFile("/var/run/secrets/kubernetes.io/serviceaccount/namespace")
+	if err != nil {
+		return "", errors.Wrap(err, "could not determine in-cluster namespace")
+	}
+	return string(data), nil
+}
+
+// kubeConfigDataProvider resolves the config from a serialized kubeconfig blob, informed via
+// --kubeconfig-data or the SHP_KUBECONFIG_DATA environment variable, for CI invocations where no
+// kubeconfig exists on disk.
+type kubeConfigDataProvider struct {
+	data      []byte
+	namespace string
+}
+
+func (p *kubeConfigDataProvider) ClientConfig() (*rest.Config, error) {
+	config, err := clientConfigFromData(p.data)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse --kubeconfig-data")
+	}
+	return config.ClientConfig()
+}
+
+func (p *kubeConfigDataProvider) Namespace() (string, error) {
+	if p.namespace != "" {
+		return p.namespace, nil
+	}
+	config, err := clientConfigFromData(p.data)
+	if err != nil {
+		return "", err
+	}
+	namespace, _, err := config.Namespace()
+	return namespace, err
+}
+
+// impersonatingProvider wraps another ConfigProvider and overlays an impersonation identity,
+// threading --as, --as-group and --as-uid through to every client built from it.
+type impersonatingProvider struct {
+	ConfigProvider
+	as      string
+	asGroup []string
+	asUID   string
+}
+
+func (p *impersonatingProvider) ClientConfig() (*rest.Config, error) {
+	config, err := p.ConfigProvider.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	if p.as == "" && len(p.asGroup) == 0 && p.asUID == "" {
+		return config, nil
+	}
+	config.Impersonate.UserName = p.as
+	config.Impersonate.Groups = p.asGroup
+	config.Impersonate.UID = p.asUID
+	return config, nil
+}
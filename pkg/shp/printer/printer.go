@@ -0,0 +1,62 @@
+// Package printer wraps k8s.io/cli-runtime/pkg/printers so `shp` subcommands can support the same
+// -o/--output values kubectl does ("name", "json", "yaml", "jsonpath=...", "go-template=..."),
+// plus a stream of structured JSON events for commands that otherwise print free-form progress.
+package printer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+// SupportedOutputs lists the -o/--output values accepted by NewPrinter, for use in flag help text.
+const SupportedOutputs = "name|json|yaml|jsonpath=...|go-template=..."
+
+// NewPrinter returns the printers.ResourcePrinter matching outputFormat. An empty outputFormat
+// behaves like "name".
+func NewPrinter(outputFormat string) (printers.ResourcePrinter, error) {
+	switch {
+	case outputFormat == "" || outputFormat == "name":
+		return &printers.NamePrinter{}, nil
+	case outputFormat == "json":
+		return &printers.JSONPrinter{}, nil
+	case outputFormat == "yaml":
+		return &printers.YAMLPrinter{}, nil
+	case strings.HasPrefix(outputFormat, "jsonpath="):
+		return printers.NewJSONPathPrinter(strings.TrimPrefix(outputFormat, "jsonpath="))
+	case strings.HasPrefix(outputFormat, "go-template="):
+		return printers.NewGoTemplatePrinter([]byte(strings.TrimPrefix(outputFormat, "go-template=")))
+	default:
+		return nil, fmt.Errorf("unsupported output format %q, must be one of %s", outputFormat, SupportedOutputs)
+	}
+}
+
+// IsJSONStream reports whether outputFormat should emit a stream of structured Event lines rather
+// than a single formatted object, currently only "json".
+func IsJSONStream(outputFormat string) bool {
+	return outputFormat == "json"
+}
+
+// Event is a single structured progress update, emitted as one JSON object per line so it can be
+// piped into jq or other tooling.
+type Event struct {
+	Type      string `json:"type"`
+	Phase     string `json:"phase,omitempty"`
+	Pod       string `json:"pod,omitempty"`
+	Container string `json:"container,omitempty"`
+	Line      string `json:"line,omitempty"`
+	Status    string `json:"status,omitempty"`
+}
+
+// WriteEvent marshals e as a single line of JSON to w.
+func WriteEvent(w io.Writer, e Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
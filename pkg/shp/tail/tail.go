@@ -0,0 +1,318 @@
+package tail
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shipwright-io/cli/pkg/shp/printer"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Tail starts and controls a log streamer per container, printing the lines read to the
+// configured io-streams as they come in.
+type Tail struct {
+	ctx       context.Context // shared context for all streamers
+	clientset kubernetes.Interface
+	streamers map[string]*PodLogStreamer // streamers per "namespace/pod/container" key
+	lock      sync.Mutex
+
+	writeLock sync.Mutex // serializes writes to out/jsonOut across concurrent streamers
+	out       io.Writer  // plain-text destination; defaults to os.Stdout until WithIOStreams is called
+	errOut    io.Writer  // fatal-streamer-error destination; defaults to os.Stderr
+	jsonOut   io.Writer  // when set, lines are emitted as printer.Event "Log" events instead of plain text
+
+	timestamps bool          // when set, each streamer's plain-text line is prefixed with its RFC3339Nano timestamp
+	since      time.Duration // when set, each streamer's first open requests only logs newer than this, mirroring PodLogOptions.SinceSeconds
+}
+
+// WithTimestamps toggles whether plain-text output is prefixed with each line's RFC3339Nano
+// timestamp, mirroring `kubectl logs --timestamps`. Applies to streamers started after the call.
+func (t *Tail) WithTimestamps(timestamps bool) {
+	t.timestamps = timestamps
+}
+
+// WithSince restricts streamers started after the call to logs newer than d, mirroring
+// `kubectl logs --since`. Once a line has been observed, a reconnect after a recoverable error
+// resumes from the last observed line instead; a reconnect before any line was read applies d again.
+func (t *Tail) WithSince(d time.Duration) {
+	t.since = d
+}
+
+// WithIOStreams points plain-text output at ioStreams.Out/ErrOut instead of the os.Stdout/Stderr
+// default, matching every other output path in the CLI.
+func (t *Tail) WithIOStreams(ioStreams *genericclioptions.IOStreams) {
+	t.out = ioStreams.Out
+	t.errOut = ioStreams.ErrOut
+}
+
+// WithJSONOutput switches the Tail to emit each log line as a printer.Event "Log" JSON object
+// written to w, instead of the plain-text "[container] line" format. Used by `-o json --follow`.
+func (t *Tail) WithJSONOutput(w io.Writer) {
+	t.jsonOut = w
+}
+
+// streamKey builds the map key identifying a single container stream.
+func streamKey(ns, pod, container string) string {
+	return fmt.Sprintf("%s/%s/%s", ns, pod, container)
+}
+
+// Start triggers a new log streamer for the informed namespace, pod and container names, unless
+// one was already started for that container since the last Stop, even if it has since finished.
+func (t *Tail) Start(ns, podName, container string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	key := streamKey(ns, podName, container)
+	if _, exists := t.streamers[key]; exists {
+		return
+	}
+	s := NewPodLogStreamer(t.ctx, t.clientset, ns, podName, container)
+	s.parent = t
+	s.timestamps = t.timestamps
+	s.since = t.since
+	t.streamers[key] = s
+	s.Start()
+}
+
+// Stop cancels every in-flight streamer, including any retry loop waiting on backoff.
+func (t *Tail) Stop() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for key, s := range t.streamers {
+		s.stop()
+		delete(t.streamers, key)
+	}
+}
+
+// NewTail instantiate a new Tail instance. Plain-text output defaults to os.Stdout/os.Stderr until
+// WithIOStreams is called.
+func NewTail(ctx context.Context, clientset kubernetes.Interface) *Tail {
+	return &Tail{
+		ctx:       ctx,
+		clientset: clientset,
+		streamers: make(map[string]*PodLogStreamer),
+		out:       defaultOut(),
+		errOut:    defaultErrOut(),
+	}
+}
+
+// streamError wraps an error observed while reading a container's log stream, flagging whether the
+// streamer should retry (recoverable) or give up (fatal).
+type streamError struct {
+	err         error
+	recoverable bool
+}
+
+func (e *streamError) Error() string {
+	return e.err.Error()
+}
+
+// classify inspects err and the container's termination state to decide whether the stream
+// can be reopened.
+func classify(err error, pod *corev1.Pod, container string) *streamError {
+	if err == nil {
+		return nil
+	}
+	// check the container's terminated state before treating EOF as transient: a container that
+	// finished normally also ends its log stream in EOF, and that case must stop the retry loop
+	// instead of reopening the stream forever
+	for _, cs := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		if cs.Name != container {
+			continue
+		}
+		if t := cs.State.Terminated; t != nil {
+			if t.ExitCode != 0 {
+				return &streamError{err: fmt.Errorf("container %q terminated with exit code %d", container, t.ExitCode), recoverable: false}
+			}
+			// clean exit: nothing more will ever be written to this container's log
+			return &streamError{recoverable: false}
+		}
+	}
+	if pod.DeletionTimestamp != nil {
+		return &streamError{err: fmt.Errorf("pod %q has been deleted", pod.GetName()), recoverable: false}
+	}
+	if err == io.EOF {
+		return &streamError{err: err, recoverable: true}
+	}
+	if _, ok := err.(net.Error); ok {
+		return &streamError{err: err, recoverable: true}
+	}
+	// anything else, e.g. connection refused or 5xx from the API-server, is assumed transient
+	return &streamError{err: err, recoverable: true}
+}
+
+// PodLogStreamer tracks the state of a single container's log stream, reopening it with
+// exponential backoff whenever a recoverable error is observed.
+type PodLogStreamer struct {
+	ctx       context.Context
+	cancel    context.CancelFunc
+	clientset kubernetes.Interface
+
+	namespace string
+	pod       string
+	container string
+
+	lastTimestamp metav1.Time // last observed log line timestamp, used to resume via SinceTime
+	backoff       time.Duration
+
+	timestamps bool          // whether to prefix printed lines with their timestamp
+	since      time.Duration // SinceSeconds requested for this container's initial stream open
+
+	parent *Tail // owns the output writers and the lock serializing writes across streamers
+}
+
+const (
+	streamBackoffInitial = 500 * time.Millisecond
+	streamBackoffMax     = 30 * time.Second
+)
+
+// NewPodLogStreamer creates a PodLogStreamer for the given container.
+func NewPodLogStreamer(ctx context.Context, clientset kubernetes.Interface, ns, pod, container string) *PodLogStreamer {
+	ctx, cancel := context.WithCancel(ctx)
+	return &PodLogStreamer{
+		ctx:       ctx,
+		cancel:    cancel,
+		clientset: clientset,
+		namespace: ns,
+		pod:       pod,
+		container: container,
+		backoff:   streamBackoffInitial,
+		// defaulted so a PodLogStreamer built directly (e.g. in tests) doesn't nil-panic on write;
+		// Tail.Start overwrites this with the owning Tail before the streamer runs
+		parent: &Tail{out: defaultOut(), errOut: defaultErrOut()},
+	}
+}
+
+// Start launches the retry loop in a separate goroutine.
+func (s *PodLogStreamer) Start() {
+	go s.run()
+}
+
+// stop cancels the streamer's context, unblocking any in-flight retry or read.
+func (s *PodLogStreamer) stop() {
+	s.cancel()
+}
+
+// run opens the log stream and keeps reopening it, with SinceTime set to the last observed line,
+// until a fatal error is seen or the context is canceled.
+func (s *PodLogStreamer) run() {
+	for {
+		if s.ctx.Err() != nil {
+			return
+		}
+		streamErr := s.stream()
+		if streamErr == nil {
+			return
+		}
+		if !streamErr.recoverable {
+			if streamErr.err != nil {
+				fmt.Fprintf(s.parent.errOut, "error tailing container %q: %s\n", s.container, streamErr.Error())
+			}
+			return
+		}
+
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-time.After(s.backoff):
+		}
+		s.backoff *= 2
+		if s.backoff > streamBackoffMax {
+			s.backoff = streamBackoffMax
+		}
+	}
+}
+
+// stream opens the actual log stream for the container and copies lines to the shared writer,
+// recording the last observed timestamp so a subsequent retry can resume with SinceTime.
+func (s *PodLogStreamer) stream() *streamError {
+	opts := &corev1.PodLogOptions{
+		Container:  s.container,
+		Follow:     true,
+		Timestamps: true,
+	}
+	if !s.lastTimestamp.IsZero() {
+		opts.SinceTime = &s.lastTimestamp
+	} else if s.since > 0 {
+		since := int64(s.since.Seconds())
+		opts.SinceSeconds = &since
+	}
+
+	req := s.clientset.CoreV1().Pods(s.namespace).GetLogs(s.pod, opts)
+	stream, err := req.Stream(s.ctx)
+	if err != nil {
+		return s.classify(err)
+	}
+	defer stream.Close()
+
+	reader := bufio.NewReader(stream)
+	for {
+		line, err := reader.ReadString('\n')
+		if len(line) > 0 {
+			s.write(line)
+		}
+		if err != nil {
+			// also routes io.EOF through classify: a container that finished cleanly ends its log
+			// stream in EOF too, and only classify can tell that apart from a transient disconnect
+			return s.classify(err)
+		}
+	}
+}
+
+// classify fetches the pod's current state and delegates to the package-level classify. A Get
+// failure that means the pod is simply gone (or access to it was revoked) is just as terminal as
+// pod.DeletionTimestamp being set, so it's classified fatal rather than defaulting to recoverable;
+// anything else (e.g. a transient API-server error) is assumed recoverable.
+func (s *PodLogStreamer) classify(err error) *streamError {
+	pod, getErr := s.clientset.CoreV1().Pods(s.namespace).Get(s.ctx, s.pod, metav1.GetOptions{})
+	if getErr != nil {
+		if apierrors.IsNotFound(getErr) {
+			return &streamError{err: fmt.Errorf("pod %q no longer exists: %w", s.pod, getErr), recoverable: false}
+		}
+		if apierrors.IsForbidden(getErr) || apierrors.IsUnauthorized(getErr) {
+			return &streamError{err: fmt.Errorf("cannot access pod %q: %w", s.pod, getErr), recoverable: false}
+		}
+		return &streamError{err: err, recoverable: true}
+	}
+	return classify(err, pod, s.container)
+}
+
+// write records the line's timestamp for resumption and forwards it to the shared, synchronized
+// writer so concurrent container streams don't interleave mid-line. When s.timestamps is set, the
+// printed plain-text line is prefixed with its RFC3339Nano timestamp, matching `kubectl logs
+// --timestamps`.
+func (s *PodLogStreamer) write(line string) {
+	ts, rest, ok := splitTimestamp(line)
+	if ok {
+		s.lastTimestamp = ts
+		line = rest
+	}
+	s.parent.writeLock.Lock()
+	defer s.parent.writeLock.Unlock()
+	if s.parent.jsonOut != nil {
+		_ = printer.WriteEvent(s.parent.jsonOut, printer.Event{
+			Type:      "Log",
+			Pod:       s.pod,
+			Container: s.container,
+			Line:      strings.TrimSuffix(line, "\n"),
+		})
+		return
+	}
+	if s.timestamps && ok {
+		fmt.Fprintf(s.parent.out, "[%s] %s %s", s.container, ts.Format(time.RFC3339Nano), line)
+		return
+	}
+	fmt.Fprintf(s.parent.out, "[%s] %s", s.container, line)
+}
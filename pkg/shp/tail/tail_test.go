@@ -0,0 +1,131 @@
+package tail
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clienttesting "k8s.io/client-go/testing"
+
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func podWithContainerState(container string, state corev1.ContainerState, deleted bool) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-pod"},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: container, State: state},
+			},
+		},
+	}
+	if deleted {
+		now := metav1.Now()
+		pod.DeletionTimestamp = &now
+	}
+	return pod
+}
+
+func TestClassify(t *testing.T) {
+	const container = "step-build"
+
+	t.Run("nil error is nil", func(t *testing.T) {
+		pod := podWithContainerState(container, corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}, false)
+		if got := classify(nil, pod, container); got != nil {
+			t.Errorf("classify(nil, ...) = %v, want nil", got)
+		}
+	})
+
+	t.Run("EOF after clean exit is fatal with no error", func(t *testing.T) {
+		pod := podWithContainerState(container, corev1.ContainerState{
+			Terminated: &corev1.ContainerStateTerminated{ExitCode: 0},
+		}, false)
+		got := classify(io.EOF, pod, container)
+		if got == nil || got.recoverable {
+			t.Fatalf("classify(io.EOF, clean exit) = %v, want non-recoverable", got)
+		}
+		if got.err != nil {
+			t.Errorf("classify(io.EOF, clean exit).err = %v, want nil", got.err)
+		}
+	})
+
+	t.Run("EOF after non-zero exit is fatal with error", func(t *testing.T) {
+		pod := podWithContainerState(container, corev1.ContainerState{
+			Terminated: &corev1.ContainerStateTerminated{ExitCode: 1},
+		}, false)
+		got := classify(io.EOF, pod, container)
+		if got == nil || got.recoverable {
+			t.Fatalf("classify(io.EOF, failed exit) = %v, want non-recoverable", got)
+		}
+		if got.err == nil {
+			t.Error("classify(io.EOF, failed exit).err = nil, want an error describing the exit code")
+		}
+	})
+
+	t.Run("EOF while still running is recoverable", func(t *testing.T) {
+		pod := podWithContainerState(container, corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}, false)
+		got := classify(io.EOF, pod, container)
+		if got == nil || !got.recoverable {
+			t.Fatalf("classify(io.EOF, still running) = %v, want recoverable", got)
+		}
+	})
+
+	t.Run("deleted pod is fatal", func(t *testing.T) {
+		pod := podWithContainerState(container, corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}, true)
+		got := classify(fmt.Errorf("connection closed"), pod, container)
+		if got == nil || got.recoverable {
+			t.Fatalf("classify(err, deleted pod) = %v, want non-recoverable", got)
+		}
+	})
+
+	t.Run("other errors while running are recoverable", func(t *testing.T) {
+		pod := podWithContainerState(container, corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}, false)
+		got := classify(fmt.Errorf("connection refused"), pod, container)
+		if got == nil || !got.recoverable {
+			t.Fatalf("classify(err, still running) = %v, want recoverable", got)
+		}
+	})
+}
+
+// TestPodLogStreamerClassifyGetFailure covers PodLogStreamer.classify's handling of a failed Get
+// on the pod itself, as opposed to the package-level classify which is handed an already-fetched
+// pod. A gone-or-forbidden pod must stop the retry loop instead of backing off forever.
+func TestPodLogStreamerClassifyGetFailure(t *testing.T) {
+	const (
+		namespace = "default"
+		pod       = "my-pod"
+		container = "step-build"
+	)
+	podResource := schema.GroupResource{Resource: "pods"}
+
+	tests := []struct {
+		name            string
+		getErr          error
+		wantRecoverable bool
+	}{
+		{name: "not found is fatal", getErr: apierrors.NewNotFound(podResource, pod), wantRecoverable: false},
+		{name: "forbidden is fatal", getErr: apierrors.NewForbidden(podResource, pod, fmt.Errorf("denied")), wantRecoverable: false},
+		{name: "unauthorized is fatal", getErr: apierrors.NewUnauthorized("denied"), wantRecoverable: false},
+		{name: "other Get error is recoverable", getErr: fmt.Errorf("etcdserver: request timed out"), wantRecoverable: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := kubefake.NewSimpleClientset()
+			clientset.PrependReactor("get", "pods", func(clienttesting.Action) (bool, runtime.Object, error) {
+				return true, nil, tt.getErr
+			})
+
+			s := NewPodLogStreamer(context.Background(), clientset, namespace, pod, container)
+			got := s.classify(io.EOF)
+			if got == nil || got.recoverable != tt.wantRecoverable {
+				t.Fatalf("classify(io.EOF) = %v, want recoverable=%v", got, tt.wantRecoverable)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package tail
+
+import (
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// defaultOut is the plain-text destination used until Tail.WithIOStreams is called.
+func defaultOut() io.Writer {
+	return os.Stdout
+}
+
+// defaultErrOut is the fatal-streamer-error destination used until Tail.WithIOStreams is called.
+func defaultErrOut() io.Writer {
+	return os.Stderr
+}
+
+// splitTimestamp separates the RFC3339Nano timestamp prefix added by PodLogOptions.Timestamps
+// from the remainder of the line.
+func splitTimestamp(line string) (metav1.Time, string, bool) {
+	parts := strings.SplitN(line, " ", 2)
+	if len(parts) != 2 {
+		return metav1.Time{}, line, false
+	}
+	t, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return metav1.Time{}, line, false
+	}
+	return metav1.Time{Time: t}, parts[1], true
+}
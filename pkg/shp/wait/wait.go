@@ -0,0 +1,112 @@
+// Package wait provides helpers to block on a BuildRun reaching a desired condition, built on top
+// of client-go's watch.Until semantics so long waits survive API-server reconnects.
+package wait
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	buildclientset "github.com/shipwright-io/build/pkg/client/clientset/versioned"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	toolswatch "k8s.io/client-go/tools/watch"
+)
+
+// ConditionFunc evaluates whether a BuildRun has reached the awaited state. A nil error with
+// false means "keep waiting"; a non-nil error aborts the wait immediately.
+type ConditionFunc func(br *buildv1alpha1.BuildRun) (bool, error)
+
+// WaitForBuildRunCondition watches the single named BuildRun and blocks until predicate returns
+// true, the context is canceled, or timeout elapses. It re-lists and restarts the watch with the
+// last observed ResourceVersion whenever the watch channel closes, so it survives API-server
+// disconnects during long waits.
+func WaitForBuildRunCondition(
+	ctx context.Context,
+	shpClientset buildclientset.Interface,
+	namespace, name string,
+	predicate ConditionFunc,
+	timeout time.Duration,
+) (*buildv1alpha1.BuildRun, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	fieldSelector := fields.OneTermEqualSelector("metadata.name", name).String()
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.FieldSelector = fieldSelector
+			return shpClientset.ShipwrightV1alpha1().BuildRuns(namespace).List(ctx, options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = fieldSelector
+			return shpClientset.ShipwrightV1alpha1().BuildRuns(namespace).Watch(ctx, options)
+		},
+	}
+
+	event, err := toolswatch.UntilWithSync(ctx, lw, &buildv1alpha1.BuildRun{}, nil, func(event watch.Event) (bool, error) {
+		switch event.Type {
+		case watch.Deleted:
+			return false, fmt.Errorf("buildrun %q was deleted before reaching the awaited condition", name)
+		case watch.Error:
+			return false, fmt.Errorf("watch error while waiting on buildrun %q", name)
+		}
+		br, ok := event.Object.(*buildv1alpha1.BuildRun)
+		if !ok {
+			return false, fmt.Errorf("unexpected object type %T watching buildrun %q", event.Object, name)
+		}
+		return predicate(br)
+	})
+	// event is still populated even when predicate itself returned a terminal error (e.g. Completed
+	// reporting a failed BuildRun), so callers can inspect the last observed state alongside err.
+	var br *buildv1alpha1.BuildRun
+	if event != nil {
+		if obj, ok := event.Object.(*buildv1alpha1.BuildRun); ok {
+			br = obj
+		}
+	}
+	return br, err
+}
+
+// conditionStatus reports the status string ("True", "False", "Unknown") of the BuildRun's
+// Succeeded condition, or "" if the condition hasn't been set yet.
+func conditionStatus(br *buildv1alpha1.BuildRun) string {
+	c := br.Status.GetCondition(buildv1alpha1.Succeeded)
+	if c == nil {
+		return ""
+	}
+	return string(c.Status)
+}
+
+// Succeeded is a ConditionFunc that is satisfied once the BuildRun's Succeeded condition is True.
+func Succeeded(br *buildv1alpha1.BuildRun) (bool, error) {
+	return conditionStatus(br) == "True", nil
+}
+
+// Failed is a ConditionFunc that is satisfied once the BuildRun's Succeeded condition is False.
+func Failed(br *buildv1alpha1.BuildRun) (bool, error) {
+	return conditionStatus(br) == "False", nil
+}
+
+// Completed is a ConditionFunc that is satisfied once the BuildRun finished, either successfully
+// or not; it returns an error when the BuildRun failed so callers can surface a non-zero exit.
+func Completed(br *buildv1alpha1.BuildRun) (bool, error) {
+	switch conditionStatus(br) {
+	case "True":
+		return true, nil
+	case "False":
+		return true, fmt.Errorf("buildrun %q failed", br.Name)
+	default:
+		return false, nil
+	}
+}
+
+// HasPodAssigned is a ConditionFunc that is satisfied once the BuildRun has a build pod running,
+// identified by its StartTime being set.
+func HasPodAssigned(br *buildv1alpha1.BuildRun) (bool, error) {
+	return br.Status.StartTime != nil, nil
+}
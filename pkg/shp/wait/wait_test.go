@@ -0,0 +1,106 @@
+package wait
+
+import (
+	"testing"
+
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func buildRunWithSucceededStatus(status corev1.ConditionStatus) *buildv1alpha1.BuildRun {
+	br := &buildv1alpha1.BuildRun{}
+	br.Status.Conditions = buildv1alpha1.Conditions{
+		{Type: buildv1alpha1.Succeeded, Status: status},
+	}
+	return br
+}
+
+func TestSucceeded(t *testing.T) {
+	tests := []struct {
+		name   string
+		status corev1.ConditionStatus
+		want   bool
+	}{
+		{name: "condition true", status: corev1.ConditionTrue, want: true},
+		{name: "condition false", status: corev1.ConditionFalse, want: false},
+		{name: "condition unknown", status: corev1.ConditionUnknown, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Succeeded(buildRunWithSucceededStatus(tt.status))
+			if err != nil {
+				t.Fatalf("Succeeded() returned unexpected error: %s", err)
+			}
+			if got != tt.want {
+				t.Errorf("Succeeded() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFailed(t *testing.T) {
+	tests := []struct {
+		name   string
+		status corev1.ConditionStatus
+		want   bool
+	}{
+		{name: "condition true", status: corev1.ConditionTrue, want: false},
+		{name: "condition false", status: corev1.ConditionFalse, want: true},
+		{name: "condition unknown", status: corev1.ConditionUnknown, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Failed(buildRunWithSucceededStatus(tt.status))
+			if err != nil {
+				t.Fatalf("Failed() returned unexpected error: %s", err)
+			}
+			if got != tt.want {
+				t.Errorf("Failed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompleted(t *testing.T) {
+	t.Run("succeeded condition true is done without error", func(t *testing.T) {
+		done, err := Completed(buildRunWithSucceededStatus(corev1.ConditionTrue))
+		if !done || err != nil {
+			t.Errorf("Completed() = (%v, %v), want (true, nil)", done, err)
+		}
+	})
+
+	t.Run("succeeded condition false is done with an error", func(t *testing.T) {
+		done, err := Completed(buildRunWithSucceededStatus(corev1.ConditionFalse))
+		if !done || err == nil {
+			t.Errorf("Completed() = (%v, %v), want (true, non-nil)", done, err)
+		}
+	})
+
+	t.Run("no condition yet keeps waiting", func(t *testing.T) {
+		done, err := Completed(&buildv1alpha1.BuildRun{})
+		if done || err != nil {
+			t.Errorf("Completed() = (%v, %v), want (false, nil)", done, err)
+		}
+	})
+}
+
+func TestHasPodAssigned(t *testing.T) {
+	t.Run("no start time keeps waiting", func(t *testing.T) {
+		done, err := HasPodAssigned(&buildv1alpha1.BuildRun{})
+		if done || err != nil {
+			t.Errorf("HasPodAssigned() = (%v, %v), want (false, nil)", done, err)
+		}
+	})
+
+	t.Run("start time set is done", func(t *testing.T) {
+		br := &buildv1alpha1.BuildRun{}
+		now := metav1.Now()
+		br.Status.StartTime = &now
+		done, err := HasPodAssigned(br)
+		if !done || err != nil {
+			t.Errorf("HasPodAssigned() = (%v, %v), want (true, nil)", done, err)
+		}
+	})
+}